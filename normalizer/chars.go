@@ -0,0 +1,111 @@
+package normalizer
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// chars wraps a string together with a pre-computed flag telling whether
+// it is entirely composed of ASCII bytes. Tokenizer corpora are
+// overwhelmingly ASCII, so callers that hold a `chars` can take a
+// byte-indexed fast path instead of paying for a `[]rune` conversion and
+// a `norm.Iter` walk on every operation.
+//
+// The idea is borrowed from fzf's `util/chars` optimization.
+type chars struct {
+	s     string
+	ascii bool
+}
+
+// newChars scans s once and records whether every byte is < utf8.RuneSelf.
+func newChars(s string) chars {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+
+	return chars{s: s, ascii: ascii}
+}
+
+// len returns the number of runes in the wrapped string, counting bytes
+// directly when the string is ASCII-only.
+func (c chars) len() int {
+	if c.ascii {
+		return len(c.s)
+	}
+
+	return len([]rune(c.s))
+}
+
+// slice returns the substring of the wrapped string in the half-open rune
+// range [start, end), indexing on bytes when the string is ASCII-only.
+func (c chars) slice(start, end int) string {
+	if c.ascii {
+		return c.s[start:end]
+	}
+
+	runes := []rune(c.s)
+	return string(runes[start:end])
+}
+
+// isASCIISpace reports whether b is one of the ASCII whitespace bytes
+// recognised by `unicode.IsSpace`.
+func isASCIISpace(b byte) bool {
+	switch b {
+	case ' ', '\t', '\n', '\v', '\f', '\r':
+		return true
+	default:
+		return false
+	}
+}
+
+// leadingSpaces returns the number of leading whitespace runes, counting
+// bytes directly on the ASCII fast path.
+func (c chars) leadingSpaces() int {
+	if c.ascii {
+		n := 0
+		for n < len(c.s) && isASCIISpace(c.s[n]) {
+			n++
+		}
+		return n
+	}
+
+	runes := []rune(c.s)
+	n := 0
+	for n < len(runes) && unicode.IsSpace(runes[n]) {
+		n++
+	}
+	return n
+}
+
+// trailingSpaces returns the number of trailing whitespace runes, counting
+// bytes directly on the ASCII fast path.
+func (c chars) trailingSpaces() int {
+	if c.ascii {
+		n := 0
+		for n < len(c.s) && isASCIISpace(c.s[len(c.s)-1-n]) {
+			n++
+		}
+		return n
+	}
+
+	runes := []rune(c.s)
+	n := 0
+	for n < len(runes) && unicode.IsSpace(runes[len(runes)-1-n]) {
+		n++
+	}
+	return n
+}
+
+// isASCII reports whether s is entirely composed of ASCII bytes.
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
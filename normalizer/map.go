@@ -0,0 +1,45 @@
+package normalizer
+
+// Map applies mapping to each rune of the normalized string, analogous to
+// strings.Map: a negative return value drops the rune, any other value
+// substitutes it. Alignments are updated via Transform so that a run of
+// dropped runes folds into a Changes: -N on the surviving rune
+// immediately preceding them (or into the leading offset, if the string
+// starts with dropped runes); substituted runes keep Changes: 0.
+func (n *Normalized) Map(mapping func(rune) rune) {
+	runes := []rune(n.normalizedString.Normalized)
+
+	var (
+		changeMap      []ChangeMap
+		initialOffset  int
+		pendingRemoved int
+		havePrev       bool
+	)
+
+	flush := func() {
+		if pendingRemoved == 0 {
+			return
+		}
+		if havePrev {
+			changeMap[len(changeMap)-1].Changes -= pendingRemoved
+		} else {
+			initialOffset = pendingRemoved
+		}
+		pendingRemoved = 0
+	}
+
+	for _, r := range runes {
+		mapped := mapping(r)
+		if mapped < 0 {
+			pendingRemoved++
+			continue
+		}
+
+		flush()
+		changeMap = append(changeMap, ChangeMap{RuneVal: string(mapped), Changes: 0})
+		havePrev = true
+	}
+	flush()
+
+	n.Transform(changeMap, initialOffset)
+}
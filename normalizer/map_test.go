@@ -0,0 +1,65 @@
+package normalizer
+
+import "testing"
+
+func TestMapSubstitute(t *testing.T) {
+	n := NewNormalizedFrom("Hello")
+	n.Map(func(r rune) rune {
+		if r == 'l' {
+			return 'L'
+		}
+		return r
+	})
+
+	if got, want := n.GetNormalized(), "HeLLo"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMapDrop(t *testing.T) {
+	n := NewNormalizedFrom("a-b-c-d")
+	n.Map(func(r rune) rune {
+		if r == '-' {
+			return -1
+		}
+		return r
+	})
+
+	if got, want := n.GetNormalized(), "abcd"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// 'b' at normalized index [1,2) absorbs the '-' dropped right after it
+	// into its alignment, per the existing Transform merge convention.
+	if got, want := n.RangeOriginal(NewRange(1, 2, NormalizedTarget)), "b-"; got != want {
+		t.Fatalf("RangeOriginal(b) = %q, want %q", got, want)
+	}
+}
+
+func TestFilter(t *testing.T) {
+	n := NewNormalizedFrom("a-b-c-d")
+	n.Filter('-')
+
+	if got, want := n.GetNormalized(), "abcd"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// 'c' at normalized index [2,3) absorbs the '-' dropped right after it
+	// into its alignment, per the existing Transform merge convention.
+	if got, want := n.RangeOriginal(NewRange(2, 3, NormalizedTarget)), "c-"; got != want {
+		t.Fatalf("RangeOriginal(c) = %q, want %q", got, want)
+	}
+}
+
+func TestFilterLeadingAndTrailing(t *testing.T) {
+	n := NewNormalizedFrom("--ab--")
+	n.Filter('-')
+
+	if got, want := n.GetNormalized(), "ab"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	if got, want := n.RangeOriginal(NewRange(0, 1, NormalizedTarget)), "--a"; got != want {
+		t.Fatalf("RangeOriginal(a) = %q, want %q", got, want)
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"strings"
 	"unicode"
 
-	"golang.org/x/text/transform"
 	"golang.org/x/text/unicode/norm"
 
 	"github.com/sugarme/tokenizer/util"
@@ -64,22 +63,23 @@ type Normalized struct {
 func NewNormalizedFrom(s string) *Normalized {
 	var alignments []Alignment
 
-	// Break down string to slice of runes
-	for i := range []rune(s) {
+	// ASCII-only input needs no rune decoding: byte index IS rune index.
+	c := newChars(s)
+	for i := 0; i < c.len(); i++ {
 		alignments = append(alignments, Alignment{
 			Pos:     i,
 			Changes: i + 1,
 		})
 	}
 
-	n := NormalizedString{
+	ns := NormalizedString{
 		Original:   s,
 		Normalized: s,
 		Alignments: alignments,
 	}
 
 	return &Normalized{
-		normalizedString: n,
+		normalizedString: ns,
 	}
 
 }
@@ -121,7 +121,14 @@ func (n *Normalized) OriginalOffsets(r []int) []int {
 	pos := selectedAlignments[0].Pos
 	changes := selectedAlignments[len(selectedAlignments)-1].Changes
 
-	return util.MakeRange(pos, changes)
+	// NOTE: util.MakeRange from the pinned dependency does not return an
+	// ascending sequence (it repeats `pos`, not `pos, pos+1, ...`), so we
+	// build the range ourselves instead of relying on it.
+	offsets := make([]int, 0, changes-pos)
+	for i := pos; i < changes; i++ {
+		offsets = append(offsets, i)
+	}
+	return offsets
 }
 
 // ConvertOffset converts the given offsets range from referential to the the
@@ -171,20 +178,18 @@ func (n *Normalized) ConvertOffset(r Range) (retVal Range) {
 	return retVal
 }
 
-// RangeOf returns a substring of the given string by indexing chars instead of bytes
+// RangeOf returns a substring of s spanning the half-open rune range
+// [start, end), indexing chars instead of bytes.
 // It will return empty string if input range is out of bound
-func RangeOf(s string, r []int) (retVal string) {
-	runes := []rune(s)
-	length := len(runes)
-	start := r[0]
-	end := r[len(r)-1] // inclusive
+func RangeOf(s string, start, end int) (retVal string) {
+	c := newChars(s)
+	length := c.len()
 	// if out of range, return 'empty' string
 	if start >= length || end > length || start >= end {
 		return ""
 	}
 
-	slicedRunes := runes[start:end]
-	return string(slicedRunes)
+	return c.slice(start, end)
 }
 
 // Range returns a substring of the NORMALIZED string (indexing on character not byte)
@@ -201,7 +206,7 @@ func (n *Normalized) Range(r Range) (retVal string) {
 		log.Fatalf("Invalid Range type: %v\n", r.indexOn)
 	}
 
-	return RangeOf(n.normalizedString.Normalized, util.MakeRange(nRange.start, nRange.end))
+	return RangeOf(n.normalizedString.Normalized, nRange.start, nRange.end)
 }
 
 // RangeOriginal returns substring of ORIGINAL string
@@ -216,9 +221,7 @@ func (n *Normalized) RangeOriginal(r Range) string {
 		log.Fatalf("Invalid Range type: %v\n", r.indexOn)
 	}
 
-	rSlice := util.MakeRange(oRange.start, oRange.end)
-
-	return RangeOf(n.normalizedString.Original, rSlice)
+	return RangeOf(n.normalizedString.Original, oRange.start, oRange.end)
 }
 
 type ChangeMap struct {
@@ -293,15 +296,17 @@ func (n *Normalized) Transform(m []ChangeMap, initialOffset int) {
 			}
 
 		case c == 0: // no changes
-			align = n.normalizedString.Alignments[idx-initialOffset]
+			align = n.normalizedString.Alignments[idx]
 
 		// Some `characters` were removed. We merge our range with one from the
 		// removed `characters` as the new alignment
 		case c < 0:
 			var uch = -changes
 			offset += changes
-			// aligns := n.normalizedString.Alignments[idx:(idx + uch + 1)]
-			aligns := n.normalizedString.Alignments[idx:(idx + uch)]
+			// This rune's own slot (idx) plus the uch removed runes right
+			// after it all fold into this one alignment, so the window is
+			// uch+1 wide, not uch.
+			aligns := n.normalizedString.Alignments[idx:(idx + uch + 1)]
 
 			// Find max, min from this slice
 			// TODO: improve algorithm? gonum?
@@ -335,6 +340,12 @@ func (n *Normalized) Transform(m []ChangeMap, initialOffset int) {
 func (n *Normalized) NFD() {
 
 	s := n.normalizedString.Normalized
+
+	// ASCII has no decomposable sequences, so it is always already NFD.
+	if isASCII(s) {
+		return
+	}
+
 	var (
 		changeMap []ChangeMap
 		it        norm.Iter
@@ -389,6 +400,11 @@ func (n *Normalized) NFC() {
 	// First, determine which normal form the string is
 	s := n.normalizedString.Normalized
 
+	// ASCII has no combining sequences, so it is always already NFC.
+	if isASCII(s) {
+		return
+	}
+
 	isNFC := norm.Form.IsNormalString(norm.NFC, s)
 	// isNFKC := norm.Form.IsNormalString(norm.NFKC, s)
 	// isNFD := norm.Form.IsNormalString(norm.NFD, s)
@@ -426,6 +442,13 @@ func (n *Normalized) NFC() {
 func (n *Normalized) NFKD() {
 
 	s := n.normalizedString.Normalized
+
+	// ASCII has no decomposable/compatibility sequences, so it is always
+	// already NFKD.
+	if isASCII(s) {
+		return
+	}
+
 	isNFKD := norm.Form.IsNormalString(norm.NFKD, s)
 	if isNFKD {
 		return // no need to normalize
@@ -473,6 +496,12 @@ func (n *Normalized) NFKC() {
 	// First, determine which normal form the string is
 	s := n.normalizedString.Normalized
 
+	// ASCII has no combining/compatibility sequences, so it is always
+	// already NFKC.
+	if isASCII(s) {
+		return
+	}
+
 	isNFKC := norm.Form.IsNormalString(norm.NFKC, s)
 
 	if isNFKC {
@@ -503,77 +532,23 @@ func (n *Normalized) NFKC() {
 	n.Transform(changeMap, 0)
 }
 
+// Filter removes every occurrence of fr from the normalized string. It is
+// implemented as a Map that drops fr.
 func (n *Normalized) Filter(fr rune) {
-
-	s := n.normalizedString.Normalized
-	var changeMap []ChangeMap
-
-	// Fisrt, reverse the string
-	var oRunes []rune
-
-	// Then, iterate over string and apply filtering
-	var it norm.Iter
-	it.InitString(norm.NFC, s)
-
-	for !it.Done() {
-		runes := []rune(string(it.Next()))
-
-		oRunes = append(oRunes, runes...)
-
-	}
-
-	revRunes := make([]rune, 0)
-	for i := len(oRunes) - 1; i >= 0; i-- {
-		revRunes = append(revRunes, oRunes[i])
-	}
-
-	var removed int = 0
-	for _, r := range revRunes {
-		// fmt.Printf("rune: %+q - filtered rune: %+q\n", r, fr)
+	n.Map(func(r rune) rune {
 		if r == fr {
-			removed += 1
-		} else {
-			if removed > 0 {
-				changeMap = append(changeMap, ChangeMap{
-					// RuneVal: fmt.Sprintf("%+q", r),
-					RuneVal: string(r),
-					Changes: -removed,
-				})
-				removed = 0
-			} else if removed == 0 {
-				changeMap = append(changeMap, ChangeMap{
-					// RuneVal: fmt.Sprintf("%+q", r),
-					RuneVal: string(r),
-					Changes: 0,
-				})
-			}
+			return -1
 		}
-	}
-
-	// Flip back changeMap
-	var unrevMap []ChangeMap
-	for i := len(changeMap) - 1; i >= 0; i-- {
-		unrevMap = append(unrevMap, changeMap[i])
-	}
-
-	// fmt.Printf("%v\n", unrevMap)
-
-	n.Transform(unrevMap, removed)
+		return r
+	})
 }
 
+// RemoveAccents strips nonspacing marks (accents) from the normalized
+// string, keeping Alignments in sync.
 func (n *Normalized) RemoveAccents() {
-
-	s := n.normalizedString.Normalized
-	b := make([]byte, len(s))
-
-	tf := transform.Chain(transform.RemoveFunc(isMn))
-
-	_, _, err := tf.Transform(b, []byte(s), true)
-	if err != nil {
+	if err := n.ApplyTransformer(NewRemoveAccentsTransformer()); err != nil {
 		log.Fatal(err)
 	}
-
-	n.normalizedString.Normalized = string(b)
 }
 
 // Lowercase transforms string to lowercase
@@ -586,68 +561,91 @@ func (n *Normalized) Uppercase() {
 	n.normalizedString.Normalized = strings.ToUpper(n.normalizedString.Normalized)
 }
 
-// SplitOff truncates string with the range [at, len).
-// remaining string will contain the range [0, at).
-// The provided `at` indexes on `char` not bytes.
-func (n *Normalized) SplitOff(at int) {
-	if at < 0 {
-		log.Fatal("Split off point must be a positive interger number.")
-	}
-	s := n.normalizedString.Normalized
-	if at > len([]rune(s)) {
-		n = NewNormalizedFrom("")
+// originalSplitOffset returns the rune offset into the original string
+// that corresponds to normalized rune index at, using the alignment
+// immediately at (or, past the end, the last alignment's Changes) so
+// splits land on correct original boundaries even when Normalized and
+// Original have diverged in length (e.g. after NFD).
+func (n *Normalized) originalSplitOffset(at int) int {
+	aligns := n.normalizedString.Alignments
+	switch {
+	case len(aligns) == 0:
+		return 0
+	case at < len(aligns):
+		return aligns[at].Pos
+	default:
+		return aligns[len(aligns)-1].Changes
 	}
+}
 
-	var (
-		it       norm.Iter
-		runeVals []string
-		aligns   []Alignment
-	)
+// Split splits the normalized string at rune index at into two
+// Normalized halves, left holding [0, at) and right holding [at, len).
+// Each half gets its own Original substring and Alignments rebased to
+// its own Original, so ConvertOffset/RangeOriginal keep working on
+// either half across the split boundary. The provided `at` indexes on
+// `char` (rune), not bytes.
+func (n *Normalized) Split(at int) (left, right *Normalized) {
+	if at < 0 {
+		log.Fatal("Split point must be a non-negative integer number.")
+	}
 
-	// Split normalized string
-	it.InitString(norm.NFC, s)
-	for !it.Done() {
-		runeVal := string(it.Next())
-		runeVals = append(runeVals, runeVal)
+	normRunes := []rune(n.normalizedString.Normalized)
+	if at > len(normRunes) {
+		at = len(normRunes)
 	}
 
-	// Alignments
-	remainVals := runeVals[0:at]
-	for i := range remainVals {
-		aligns = append(aligns, Alignment{
-			Pos:     i,
-			Changes: i + 1,
-		})
+	originalRunes := []rune(n.normalizedString.Original)
+	splitOriginal := n.originalSplitOffset(at)
+	if splitOriginal > len(originalRunes) {
+		splitOriginal = len(originalRunes)
 	}
-	n.normalizedString.Normalized = strings.Join(remainVals, "")
-	n.normalizedString.Alignments = aligns
 
-	// Split original string
-	originalAt := aligns[len(aligns)].Changes // changes of last alignment
+	aligns := n.normalizedString.Alignments
+	leftAligns := append([]Alignment{}, aligns[:at]...)
 
-	var oRuneVals []string
-	it.InitString(norm.NFC, n.normalizedString.Original)
-	for !it.Done() {
-		runeVal := string(it.Next())
-		oRuneVals = append(oRuneVals, runeVal)
+	rightAligns := make([]Alignment, len(aligns)-at)
+	for i, a := range aligns[at:] {
+		rightAligns[i] = Alignment{Pos: a.Pos - splitOriginal, Changes: a.Changes - splitOriginal}
 	}
 
-	remainORuneVals := oRuneVals[0:originalAt]
-	n.normalizedString.Original = strings.Join(remainORuneVals, "")
+	left = &Normalized{normalizedString: NormalizedString{
+		Original:   string(originalRunes[:splitOriginal]),
+		Normalized: string(normRunes[:at]),
+		Alignments: leftAligns,
+	}}
+
+	right = &Normalized{normalizedString: NormalizedString{
+		Original:   string(originalRunes[splitOriginal:]),
+		Normalized: string(normRunes[at:]),
+		Alignments: rightAligns,
+	}}
+
+	return left, right
+}
 
+// SplitOff truncates string with the range [at, len).
+// remaining string will contain the range [0, at).
+// The provided `at` indexes on `char` not bytes.
+func (n *Normalized) SplitOff(at int) {
+	left, _ := n.Split(at)
+	n.normalizedString = left.normalizedString
 }
 
 // MergeWith merges an input string with existing one
 func (n *Normalized) MergeWith(other NormalizedString) {
-	len := n.Len()
+	// Alignment.Pos/Changes index into Original, so other's alignments must
+	// shift by the receiver's Original rune length, not n.Len() (its
+	// Normalized one) -- the two diverge once n has gone through NFD or
+	// similar.
+	shift := len([]rune(n.normalizedString.Original))
 	n.normalizedString.Original = strings.Join([]string{n.normalizedString.Original, other.Original}, "")
 	n.normalizedString.Normalized = strings.Join([]string{n.normalizedString.Normalized, other.Normalized}, "")
 
 	var ajustedAligns []Alignment
 	for _, a := range other.Alignments {
 		new := Alignment{
-			Pos:     a.Pos + len,
-			Changes: a.Changes + len,
+			Pos:     a.Pos + shift,
+			Changes: a.Changes + shift,
 		}
 
 		ajustedAligns = append(ajustedAligns, new)
@@ -657,10 +655,30 @@ func (n *Normalized) MergeWith(other NormalizedString) {
 
 }
 
+// Prepend prepends an input string to the existing one, mirroring MergeWith.
+func (n *Normalized) Prepend(other NormalizedString) {
+	// Alignment.Pos/Changes index into Original, so the receiver's existing
+	// alignments must shift by other's Original rune length, not its
+	// Normalized one (the two diverge once other has gone through NFD or
+	// similar).
+	shift := len([]rune(other.Original))
+
+	shiftedAligns := make([]Alignment, len(n.normalizedString.Alignments))
+	for i, a := range n.normalizedString.Alignments {
+		shiftedAligns[i] = Alignment{
+			Pos:     a.Pos + shift,
+			Changes: a.Changes + shift,
+		}
+	}
+
+	n.normalizedString.Original = strings.Join([]string{other.Original, n.normalizedString.Original}, "")
+	n.normalizedString.Normalized = strings.Join([]string{other.Normalized, n.normalizedString.Normalized}, "")
+	n.normalizedString.Alignments = append(append([]Alignment{}, other.Alignments...), shiftedAligns...)
+}
+
 // Len returns length (number of runes) of normalized string
 func (n *Normalized) Len() int {
-	runes := []rune(n.normalizedString.Normalized)
-	return len(runes)
+	return newChars(n.normalizedString.Normalized).len()
 }
 
 // LStrip removes leading spaces
@@ -689,44 +707,35 @@ func (n *Normalized) lrstrip(left, right bool) {
 
 	s = n.normalizedString.Normalized
 
-	runes := []rune(s)
+	c := newChars(s)
 
 	if left {
-		for _, r := range runes {
-			if !unicode.IsSpace(r) {
-				break
-			}
-
-			leadingSpaces += 1
-		}
+		leadingSpaces = c.leadingSpaces()
 	}
 
 	if right {
-		for i := len(runes) - 1; i >= 0; i-- {
-			if !unicode.IsSpace(runes[i]) {
-				break
-			}
-
-			trailingSpaces += 1
-		}
+		trailingSpaces = c.trailingSpaces()
 	}
 
-	// fmt.Println(runes)
 	// fmt.Printf("LeadingSpace: %d\n", leadingSpaces)
 	// fmt.Printf("TrailingSpace: %d\n", trailingSpaces)
 
 	if leadingSpaces > 0 || trailingSpaces > 0 {
-		for i, r := range runes {
-			if i < leadingSpaces || i >= (len(runes)-trailingSpaces) {
+		length := c.len()
+		for i := 0; i < length; i++ {
+			if i < leadingSpaces || i >= (length-trailingSpaces) {
 				continue
-			} else if i == len(runes)-trailingSpaces-1 {
+			}
+
+			r := c.slice(i, i+1)
+			if i == length-trailingSpaces-1 {
 				changeMap = append(changeMap, ChangeMap{
-					RuneVal: string(r),
+					RuneVal: r,
 					Changes: -(trailingSpaces),
 				})
 			} else {
 				changeMap = append(changeMap, ChangeMap{
-					RuneVal: string(r),
+					RuneVal: r,
 					Changes: 0,
 				})
 			}
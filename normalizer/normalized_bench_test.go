@@ -0,0 +1,78 @@
+package normalizer
+
+import "testing"
+
+// benchASCII and benchUnicode are representative of the two classes of
+// input a tokenizer normalizer sees in practice: plain ASCII (the
+// overwhelming majority of most corpora) and text containing multi-byte
+// runes that defeat the ASCII fast path.
+const (
+	benchASCII   = "The quick brown fox jumps over the lazy dog, again and again and again."
+	benchUnicode = "Le renard brun rapide saute par-dessus le chien paresseux, à nouveau et encore une fois, 日本語テキスト。"
+)
+
+func BenchmarkNewNormalizedFromASCII(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(benchASCII)
+	}
+}
+
+func BenchmarkNewNormalizedFromUnicode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(benchUnicode)
+	}
+}
+
+func BenchmarkLenASCII(b *testing.B) {
+	n := NewNormalizedFrom(benchASCII)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n.Len()
+	}
+}
+
+func BenchmarkLenUnicode(b *testing.B) {
+	n := NewNormalizedFrom(benchUnicode)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		n.Len()
+	}
+}
+
+func BenchmarkStripASCII(b *testing.B) {
+	s := "   " + benchASCII + "   "
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(s).Strip()
+	}
+}
+
+func BenchmarkStripUnicode(b *testing.B) {
+	s := "   " + benchUnicode + "   "
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(s).Strip()
+	}
+}
+
+func BenchmarkFilterASCII(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(benchASCII).Filter(' ')
+	}
+}
+
+func BenchmarkFilterUnicode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(benchUnicode).Filter(' ')
+	}
+}
+
+func BenchmarkNFCASCII(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(benchASCII).NFC()
+	}
+}
+
+func BenchmarkNFCUnicode(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		NewNormalizedFrom(benchUnicode).NFC()
+	}
+}
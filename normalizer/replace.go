@@ -0,0 +1,160 @@
+package normalizer
+
+import (
+	"regexp"
+	"strings"
+)
+
+// runeRange is a half-open range of rune indices into a normalized
+// string, used internally to describe a match to be replaced.
+type runeRange struct {
+	start, end int
+}
+
+// Replace replaces the first occurrence of pattern in the normalized
+// string with replacement, keeping Alignments in sync. It is a no-op if
+// pattern does not occur or is empty.
+func (n *Normalized) Replace(pattern, replacement string) {
+	n.replaceRuneRanges(findLiteralMatches(n.normalizedString.Normalized, pattern, false), replacement)
+}
+
+// ReplaceAll replaces every non-overlapping occurrence of pattern in the
+// normalized string with replacement, keeping Alignments in sync.
+func (n *Normalized) ReplaceAll(pattern, replacement string) {
+	n.replaceRuneRanges(findLiteralMatches(n.normalizedString.Normalized, pattern, true), replacement)
+}
+
+// ReplaceRegex replaces every match of re in the normalized string with
+// replacement, keeping Alignments in sync.
+func (n *Normalized) ReplaceRegex(re *regexp.Regexp, replacement string) {
+	s := n.normalizedString.Normalized
+	byteMatches := re.FindAllStringIndex(s, -1)
+	if len(byteMatches) == 0 {
+		return
+	}
+
+	matches := make([]runeRange, 0, len(byteMatches))
+	runeIdx := 0
+	byteIdx := 0
+	for _, m := range byteMatches {
+		runeIdx += len([]rune(s[byteIdx:m[0]]))
+		start := runeIdx
+		runeIdx += len([]rune(s[m[0]:m[1]]))
+		matches = append(matches, runeRange{start: start, end: runeIdx})
+		byteIdx = m[1]
+	}
+
+	n.replaceRuneRanges(matches, replacement)
+}
+
+// findLiteralMatches returns the non-overlapping rune ranges at which
+// pattern occurs in s, left to right. If all is false, only the first
+// occurrence is returned.
+func findLiteralMatches(s, pattern string, all bool) []runeRange {
+	if pattern == "" {
+		return nil
+	}
+
+	var matches []runeRange
+	runeIdx := 0
+	byteIdx := 0
+	for {
+		rel := strings.Index(s[byteIdx:], pattern)
+		if rel < 0 {
+			break
+		}
+
+		matchByteStart := byteIdx + rel
+		runeIdx += len([]rune(s[byteIdx:matchByteStart]))
+		start := runeIdx
+		runeIdx += len([]rune(pattern))
+		matches = append(matches, runeRange{start: start, end: runeIdx})
+
+		byteIdx = matchByteStart + len(pattern)
+		if !all {
+			break
+		}
+	}
+
+	return matches
+}
+
+// replaceRuneRanges rewrites the normalized string, substituting
+// replacement for each rune range in ranges (sorted, non-overlapping),
+// and drives the result through Transform so Alignments stay consistent.
+//
+// For a match at rune range [a,b) replaced by k runes, the first
+// replacement rune carries Changes: -(b-a-1) whenever more than one
+// original rune is consumed (merging the removed runes into the single
+// surviving alignment), and every replacement rune beyond the first
+// carries Changes: 1, matching how NFD/NFKD record newly-inserted runes.
+func (n *Normalized) replaceRuneRanges(ranges []runeRange, replacement string) {
+	if len(ranges) == 0 {
+		return
+	}
+
+	runes := []rune(n.normalizedString.Normalized)
+	replRunes := []rune(replacement)
+
+	var (
+		changeMap      []ChangeMap
+		initialOffset  int
+		pendingRemoved int
+		havePrev       bool
+	)
+
+	// flush attaches runes removed since the last surviving rune onto that
+	// rune's Changes (or, if nothing has survived yet, onto initialOffset).
+	flush := func() {
+		if pendingRemoved == 0 {
+			return
+		}
+		if havePrev {
+			changeMap[len(changeMap)-1].Changes -= pendingRemoved
+		} else {
+			initialOffset = pendingRemoved
+		}
+		pendingRemoved = 0
+	}
+
+	mi, i := 0, 0
+	for i < len(runes) || (mi < len(ranges) && ranges[mi].start == i) {
+		if mi < len(ranges) && ranges[mi].start == i {
+			m := ranges[mi]
+			length := m.end - m.start
+
+			if len(replRunes) == 0 {
+				pendingRemoved += length
+			} else {
+				flush()
+				for j, r := range replRunes {
+					var changes int
+					switch {
+					case length == 0:
+						changes = 1 // pure insertion, nothing removed to merge with
+					case j == 0 && length > 1:
+						changes = -(length - 1)
+					case j == 0:
+						changes = 0
+					default:
+						changes = 1
+					}
+					changeMap = append(changeMap, ChangeMap{RuneVal: string(r), Changes: changes})
+				}
+				havePrev = true
+			}
+
+			i = m.end
+			mi++
+			continue
+		}
+
+		flush()
+		changeMap = append(changeMap, ChangeMap{RuneVal: string(runes[i]), Changes: 0})
+		havePrev = true
+		i++
+	}
+	flush()
+
+	n.Transform(changeMap, initialOffset)
+}
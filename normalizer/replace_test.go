@@ -0,0 +1,75 @@
+package normalizer
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestReplace(t *testing.T) {
+	n := NewNormalizedFrom("a--b--c")
+	n.Replace("--", " ")
+
+	if got, want := n.GetNormalized(), "a b--c"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestReplaceAll(t *testing.T) {
+	n := NewNormalizedFrom("a--b--c")
+	n.ReplaceAll("--", " ")
+
+	if got, want := n.GetNormalized(), "a b c"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	// The single space at normalized index [1,2) should map back to the
+	// two dashes it replaced in the original string.
+	if got, want := n.RangeOriginal(NewRange(1, 2, NormalizedTarget)), "--"; got != want {
+		t.Fatalf("RangeOriginal(space) = %q, want %q", got, want)
+	}
+
+	// 'b' at normalized index [2,3) is untouched and should map back to itself.
+	if got, want := n.RangeOriginal(NewRange(2, 3, NormalizedTarget)), "b"; got != want {
+		t.Fatalf("RangeOriginal(b) = %q, want %q", got, want)
+	}
+}
+
+func TestReplaceRegex(t *testing.T) {
+	n := NewNormalizedFrom("foo   bar\tbaz")
+	n.ReplaceRegex(regexp.MustCompile(`\s+`), " ")
+
+	if got, want := n.GetNormalized(), "foo bar baz"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// TestReplaceRoundTrip chains a ReplaceAll and a Replace and checks that
+// RangeOriginal still maps each region of the normalized string back to
+// the correct part of the original, including the parts untouched by the
+// second replacement.
+func TestReplaceRoundTrip(t *testing.T) {
+	n := NewNormalizedFrom("foo::bar::baz")
+	n.ReplaceAll("::", "-")
+	n.Replace("bar", "qux")
+
+	if got, want := n.GetNormalized(), "foo-qux-baz"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	cases := []struct {
+		r    Range
+		want string
+	}{
+		{NewRange(0, 3, NormalizedTarget), "foo"}, // untouched prefix
+		{NewRange(3, 4, NormalizedTarget), "::"},  // first "::" -> "-"
+		{NewRange(4, 7, NormalizedTarget), "bar"}, // "bar" -> "qux", from the second Replace
+		{NewRange(7, 8, NormalizedTarget), "::"},  // second "::" -> "-"
+		{NewRange(8, 10, NormalizedTarget), "ba"}, // untouched suffix
+	}
+
+	for _, c := range cases {
+		if got := n.RangeOriginal(c.r); got != c.want {
+			t.Errorf("RangeOriginal(%+v) = %q, want %q", c.r, got, c.want)
+		}
+	}
+}
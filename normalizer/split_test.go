@@ -0,0 +1,140 @@
+package normalizer
+
+import "testing"
+
+func TestSplitOff(t *testing.T) {
+	n := NewNormalizedFrom("hello world")
+	n.SplitOff(5)
+
+	if got, want := n.GetNormalized(), "hello"; got != want {
+		t.Fatalf("Normalized = %q, want %q", got, want)
+	}
+	if got, want := n.GetOriginal(), "hello"; got != want {
+		t.Fatalf("Original = %q, want %q", got, want)
+	}
+}
+
+// A split point past the end of the string must leave n unchanged
+// rather than resetting it to empty.
+func TestSplitOffOverflowIsNoop(t *testing.T) {
+	n := NewNormalizedFrom("hi")
+	n.SplitOff(100)
+
+	if got, want := n.GetNormalized(), "hi"; got != want {
+		t.Fatalf("Normalized = %q, want %q", got, want)
+	}
+	if got, want := n.GetOriginal(), "hi"; got != want {
+		t.Fatalf("Original = %q, want %q", got, want)
+	}
+}
+
+// TestSplit exercises a case where Normalized has diverged in length from
+// Original (NFD decomposes 'é' into 'e' + a combining acute accent, so
+// Normalized has one more rune than Original) and checks that both halves
+// get correctly rebased Alignments and Original substrings.
+func TestSplit(t *testing.T) {
+	n := NewNormalizedFrom("héllo")
+	n.NFD()
+
+	// Normalized runes: h, e, <combining acute>, l, l, o -- split right
+	// after the combining accent.
+	left, right := n.Split(3)
+
+	if got, want := left.GetNormalized(), "hé"; got != want {
+		t.Fatalf("left.Normalized = %q, want %q", got, want)
+	}
+	if got, want := left.GetOriginal(), "hé"; got != want {
+		t.Fatalf("left.Original = %q, want %q", got, want)
+	}
+	if got, want := right.GetNormalized(), "llo"; got != want {
+		t.Fatalf("right.Normalized = %q, want %q", got, want)
+	}
+	if got, want := right.GetOriginal(), "llo"; got != want {
+		t.Fatalf("right.Original = %q, want %q", got, want)
+	}
+
+	// The two halves' Original strings must reassemble the original input.
+	if got, want := left.GetOriginal()+right.GetOriginal(), n.GetOriginal(); got != want {
+		t.Fatalf("left.Original+right.Original = %q, want %q", got, want)
+	}
+
+	// ConvertOffset must still round-trip on each half, rebased to that
+	// half's own Original.
+	if got, want := left.RangeOriginal(NewRange(0, 1, NormalizedTarget)), "h"; got != want {
+		t.Fatalf("left.RangeOriginal(h) = %q, want %q", got, want)
+	}
+	if got, want := right.RangeOriginal(NewRange(0, 1, NormalizedTarget)), "l"; got != want {
+		t.Fatalf("right.RangeOriginal(l) = %q, want %q", got, want)
+	}
+
+	gotRange := right.ConvertOffset(NewRange(1, 2, NormalizedTarget))
+	if got, want := RangeOf(right.GetOriginal(), gotRange.start, gotRange.end), "l"; got != want {
+		t.Fatalf("right.ConvertOffset round trip = %q, want %q", got, want)
+	}
+}
+
+func TestPrepend(t *testing.T) {
+	n := NewNormalizedFrom("world")
+	n.Prepend(NewNormalizedFrom("hello ").Get())
+
+	if got, want := n.GetNormalized(), "hello world"; got != want {
+		t.Fatalf("Normalized = %q, want %q", got, want)
+	}
+	if got, want := n.GetOriginal(), "hello world"; got != want {
+		t.Fatalf("Original = %q, want %q", got, want)
+	}
+
+	// The prepended part and the original part should each still map back
+	// to their own original substrings.
+	if got, want := n.RangeOriginal(NewRange(0, 5, NormalizedTarget)), "hello"; got != want {
+		t.Fatalf("RangeOriginal(hello) = %q, want %q", got, want)
+	}
+	if got, want := n.RangeOriginal(NewRange(6, 10, NormalizedTarget)), "worl"; got != want {
+		t.Fatalf("RangeOriginal(worl) = %q, want %q", got, want)
+	}
+}
+
+// TestPrependDivergentAlignments prepends a piece whose Original and
+// Normalized rune counts differ (NFD decomposition), so the receiver's
+// own alignments must shift by the prepended Original's rune length, not
+// its Normalized one.
+func TestPrependDivergentAlignments(t *testing.T) {
+	other := NewNormalizedFrom("é ")
+	other.NFD() // Original "é " is 2 runes; Normalized "e´ " is 3 runes.
+
+	n := NewNormalizedFrom("world")
+	n.Prepend(other.Get())
+
+	if got, want := n.GetOriginal(), "é world"; got != want {
+		t.Fatalf("Original = %q, want %q", got, want)
+	}
+
+	// 'w' sits right after the 3-rune decomposed prefix in Normalized, and
+	// must still map back to just 'w' in the combined Original.
+	wIdx := other.Len()
+	if got, want := n.RangeOriginal(NewRange(wIdx, wIdx+1, NormalizedTarget)), "w"; got != want {
+		t.Fatalf("RangeOriginal(w) = %q, want %q", got, want)
+	}
+}
+
+// TestMergeWithDivergentAlignments mirrors TestPrependDivergentAlignments but
+// for MergeWith: the receiver's Original and Normalized rune counts differ
+// (NFD decomposition), so the appended piece's alignments must shift by the
+// receiver's Original rune length, not its Normalized one.
+func TestMergeWithDivergentAlignments(t *testing.T) {
+	n := NewNormalizedFrom("é ")
+	n.NFD() // Original "é " is 2 runes; Normalized "e´ " is 3 runes.
+
+	n.MergeWith(NewNormalizedFrom("world").Get())
+
+	if got, want := n.GetOriginal(), "é world"; got != want {
+		t.Fatalf("Original = %q, want %q", got, want)
+	}
+
+	// 'w' sits right after the 3-rune decomposed prefix in Normalized, and
+	// must still map back to just 'w' in the combined Original.
+	wIdx := n.Len() - 5
+	if got, want := n.RangeOriginal(NewRange(wIdx, wIdx+1, NormalizedTarget)), "w"; got != want {
+		t.Fatalf("RangeOriginal(w) = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,300 @@
+package normalizer
+
+import (
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// lowercaseTransformer and uppercaseTransformer adapt (*Normalized).Lowercase
+// and Uppercase to the transform.Transformer interface so they can be
+// composed with other golang.org/x/text transformers through NewPipeline.
+type lowercaseTransformer struct{ transform.NopResetter }
+
+func (lowercaseTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	return mapRunes(dst, src, atEOF, unicode.ToLower)
+}
+
+type uppercaseTransformer struct{ transform.NopResetter }
+
+func (uppercaseTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	return mapRunes(dst, src, atEOF, unicode.ToUpper)
+}
+
+// mapRunes applies f to every complete rune in src, following the usual
+// transform.Transformer contract of leaving a trailing incomplete rune in
+// src for the next call.
+func mapRunes(dst, src []byte, atEOF bool, f func(rune) rune) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+
+		r = f(r)
+		if nDst+utf8.RuneLen(r) > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+
+		nDst += utf8.EncodeRune(dst[nDst:], r)
+		nSrc += size
+	}
+	return nDst, nSrc, nil
+}
+
+// stripTransformer trims unicode.IsSpace runes from the left and/or right
+// of its input. Trailing spaces are buffered until a non-space rune
+// arrives or atEOF is reached, since they are only genuinely "trailing"
+// once we know nothing more follows.
+type stripTransformer struct {
+	left, right  bool
+	seenNonSpace bool
+	pending      []byte
+}
+
+func (t *stripTransformer) Reset() {
+	t.seenNonSpace = false
+	t.pending = nil
+}
+
+func (t *stripTransformer) Transform(dst, src []byte, atEOF bool) (nDst, nSrc int, err error) {
+	for nSrc < len(src) {
+		r, size := utf8.DecodeRune(src[nSrc:])
+		if r == utf8.RuneError && size == 1 && !atEOF && !utf8.FullRune(src[nSrc:]) {
+			return nDst, nSrc, transform.ErrShortSrc
+		}
+
+		isSpace := unicode.IsSpace(r)
+
+		if t.left && !t.seenNonSpace && isSpace {
+			nSrc += size
+			continue
+		}
+		t.seenNonSpace = true
+
+		if t.right && isSpace {
+			t.pending = append(t.pending, src[nSrc:nSrc+size]...)
+			nSrc += size
+			continue
+		}
+
+		if len(t.pending) > 0 {
+			if nDst+len(t.pending) > len(dst) {
+				return nDst, nSrc, transform.ErrShortDst
+			}
+			nDst += copy(dst[nDst:], t.pending)
+			t.pending = t.pending[:0]
+		}
+
+		if nDst+size > len(dst) {
+			return nDst, nSrc, transform.ErrShortDst
+		}
+		nDst += copy(dst[nDst:], src[nSrc:nSrc+size])
+		nSrc += size
+	}
+
+	if atEOF && t.right {
+		// Whatever is left pending at EOF is genuinely trailing: drop it.
+		t.pending = t.pending[:0]
+	}
+	return nDst, nSrc, nil
+}
+
+// NewLowercaseTransformer returns a transform.Transformer that lowercases
+// its input rune by rune.
+func NewLowercaseTransformer() transform.Transformer {
+	return lowercaseTransformer{}
+}
+
+// NewUppercaseTransformer returns a transform.Transformer that uppercases
+// its input rune by rune.
+func NewUppercaseTransformer() transform.Transformer {
+	return uppercaseTransformer{}
+}
+
+// NewStripTransformer returns a transform.Transformer that removes
+// leading and/or trailing unicode.IsSpace runes from its input.
+func NewStripTransformer(left, right bool) transform.Transformer {
+	return &stripTransformer{left: left, right: right}
+}
+
+// NewFilterTransformer returns a transform.Transformer that drops every
+// occurrence of fr from its input.
+func NewFilterTransformer(fr rune) transform.Transformer {
+	return transform.RemoveFunc(func(r rune) bool { return r == fr })
+}
+
+// NewRemoveAccentsTransformer returns a transform.Transformer that strips
+// nonspacing marks (accents) from its input. It decomposes to NFD so that
+// accents become separate combining runes, removes them, then recomposes
+// to NFC -- the standard golang.org/x/text recipe for accent removal.
+func NewRemoveAccentsTransformer() transform.Transformer {
+	return transform.Chain(norm.NFD, transform.RemoveFunc(isMn), norm.NFC)
+}
+
+// NewPipeline composes steps into a single Transformer that runs them in
+// sequence, e.g. NewPipeline(norm.NFD, NewLowercaseTransformer()). The
+// forms norm.NFD, norm.NFC, norm.NFKD and norm.NFKC are themselves
+// already transform.Transformer implementations and can be passed
+// directly.
+func NewPipeline(steps ...transform.Transformer) transform.Transformer {
+	return transform.Chain(steps...)
+}
+
+// ApplyTransformer drives t once over the current normalized buffer (so
+// stateful transformers like NewStripTransformer see the whole input, not
+// isolated runes) and updates Alignments to match, so RangeOriginal/
+// ConvertOffset keep mapping back to the correct original offsets
+// afterwards.
+//
+// Alignments are reconstructed by diffing the input and output rune
+// sequences with a minimum-edit-distance alignment (see diffRunes), which
+// pairs a rune of output with a rune of input whenever that's cheaper
+// than deleting and re-inserting it. That keeps e.g. case mapping and
+// NFD/NFC composition tied to their original position instead of being
+// treated as an unrelated deletion plus insertion, and makes this exact
+// for any transform.Transformer that doesn't reorder runes -- which
+// covers every transformer in this package, including transform.Chain
+// combinations of them.
+func (n *Normalized) ApplyTransformer(t transform.Transformer) error {
+	t.Reset()
+	in := n.normalizedString.Normalized
+	out, _, err := transform.String(t, in)
+	if err != nil {
+		return err
+	}
+
+	steps := diffRunes([]rune(in), []rune(out))
+
+	var (
+		changeMap      []ChangeMap
+		initialOffset  int
+		pendingRemoved int
+		havePrev       bool
+	)
+
+	flush := func() {
+		if pendingRemoved == 0 {
+			return
+		}
+		if havePrev {
+			changeMap[len(changeMap)-1].Changes -= pendingRemoved
+		} else {
+			initialOffset = pendingRemoved
+		}
+		pendingRemoved = 0
+	}
+
+	for _, s := range steps {
+		switch s.op {
+		case opDelete:
+			pendingRemoved++
+		case opMatch:
+			flush()
+			changeMap = append(changeMap, ChangeMap{RuneVal: string(s.r), Changes: 0})
+			havePrev = true
+		case opInsert:
+			flush()
+			changeMap = append(changeMap, ChangeMap{RuneVal: string(s.r), Changes: 1})
+			havePrev = true
+		}
+	}
+	flush()
+
+	n.Transform(changeMap, initialOffset)
+	return nil
+}
+
+// editOp is one step of the alignment a diffRunes backtrace produces.
+type editOp int
+
+const (
+	// opMatch pairs one input rune with one output rune (which may have a
+	// different value, e.g. a case-mapped or composed rune).
+	opMatch editOp = iota
+	// opInsert is an output rune with no corresponding input rune.
+	opInsert
+	// opDelete is an input rune with no corresponding output rune.
+	opDelete
+)
+
+type editStep struct {
+	op editOp
+	r  rune // meaningful for opMatch/opInsert
+}
+
+// diffRunes computes a minimum-edit-distance alignment between a and b
+// (unit cost for insert/delete/substitute, zero for an exact match) and
+// backtracks it into a left-to-right sequence of editSteps. A substitution
+// costs less than a delete+insert pair, so a transformer which changes a
+// rune's value without changing its position -- case mapping, NFC
+// composing a base rune with a combining mark -- is recorded as the rune
+// being kept, not as unrelated deletion/insertion.
+// When a match ties with an insert (e.g. NFD decomposing one input rune
+// into several output runes), the insert is preferred so the match lands
+// on the first output rune and the rest are recorded as trailing inserts.
+// Likewise, when a match ties with a delete (e.g. NFC composing a base
+// rune and its combining marks into one output rune), the delete is
+// preferred so the match lands on the base rune and the marks are
+// recorded as trailing deletes. Both match how the package's own NFD/NFC
+// alignments are built.
+//
+// This is O(len(a)*len(b)) time and space; ApplyTransformer calls it on
+// the whole normalized buffer, so the cost grows with the product of the
+// input and output rune lengths.
+func diffRunes(a, b []rune) []editStep {
+	n, m := len(a), len(b)
+
+	dp := make([][]int, n+1)
+	for i := range dp {
+		dp[i] = make([]int, m+1)
+	}
+	for i := 0; i <= n; i++ {
+		dp[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		dp[0][j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			best := dp[i-1][j-1] + substCost(a[i-1], b[j-1]) // match/substitute
+			if v := dp[i-1][j] + 1; v < best {
+				best = v // delete a[i-1]
+			}
+			if v := dp[i][j-1] + 1; v < best {
+				best = v // insert b[j-1]
+			}
+			dp[i][j] = best
+		}
+	}
+
+	steps := make([]editStep, 0, n+m)
+	for i, j := n, m; i > 0 || j > 0; {
+		switch {
+		case j > 0 && dp[i][j] == dp[i][j-1]+1:
+			steps = append(steps, editStep{op: opInsert, r: b[j-1]})
+			j--
+		case i > 0 && dp[i][j] == dp[i-1][j]+1:
+			steps = append(steps, editStep{op: opDelete})
+			i--
+		default:
+			steps = append(steps, editStep{op: opMatch, r: b[j-1]})
+			i--
+			j--
+		}
+	}
+
+	for l, r := 0, len(steps)-1; l < r; l, r = l+1, r-1 {
+		steps[l], steps[r] = steps[r], steps[l]
+	}
+	return steps
+}
+
+func substCost(a, b rune) int {
+	if a == b {
+		return 0
+	}
+	return 1
+}
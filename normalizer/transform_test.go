@@ -0,0 +1,58 @@
+package normalizer
+
+import (
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TestApplyTransformerStripKeepsInternalWhitespace drives NewStripTransformer
+// through NewPipeline and ApplyTransformer on an input with leading,
+// trailing, AND internal whitespace. Driving it a rune at a time (the old
+// implementation) would see every rune in isolation and strip the internal
+// space too; driving it once over the whole buffer must not.
+func TestApplyTransformerStripKeepsInternalWhitespace(t *testing.T) {
+	n := NewNormalizedFrom("  hello world  ")
+	if err := n.ApplyTransformer(NewPipeline(NewStripTransformer(true, true))); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := n.GetNormalized(), "hello world"; got != want {
+		t.Fatalf("Normalized = %q, want %q", got, want)
+	}
+
+	// 'h' at normalized index [0,1) absorbs the 2 leading spaces.
+	if got, want := n.RangeOriginal(NewRange(0, 1, NormalizedTarget)), "  h"; got != want {
+		t.Fatalf("RangeOriginal(h) = %q, want %q", got, want)
+	}
+	// The internal space survives untouched, at its original position.
+	if got, want := n.RangeOriginal(NewRange(5, 6, NormalizedTarget)), " "; got != want {
+		t.Fatalf("RangeOriginal(internal space) = %q, want %q", got, want)
+	}
+	// The full Original still reassembles, trailing spaces included.
+	if got, want := n.GetOriginal(), "  hello world  "; got != want {
+		t.Fatalf("Original = %q, want %q", got, want)
+	}
+}
+
+// TestApplyTransformerNFCComposesDecomposed pipes a decomposed rune sequence
+// (a base letter followed by a combining accent) through norm.NFC, which
+// only composes when it sees the accent immediately following its base
+// rune -- exactly the cross-rune state the old per-rune driving discarded.
+func TestApplyTransformerNFCComposesDecomposed(t *testing.T) {
+	decomposed := "e" + "́" + "llo" // "e" + combining acute accent + "llo"
+	n := NewNormalizedFrom(decomposed)
+	if err := n.ApplyTransformer(NewPipeline(norm.NFC)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := n.GetNormalized(), "éllo"; got != want {
+		t.Fatalf("Normalized = %q, want %q", got, want)
+	}
+
+	// The composed 'é' absorbs both original runes (the base letter and the
+	// combining accent) into its alignment.
+	if got, want := n.RangeOriginal(NewRange(0, 1, NormalizedTarget)), decomposed[:3]; got != want {
+		t.Fatalf("RangeOriginal(é) = %q, want %q", got, want)
+	}
+}